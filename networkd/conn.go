@@ -0,0 +1,95 @@
+// Copyright 2023 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkd
+
+import (
+	"io"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/pkg/errors"
+)
+
+// connection returns the cached D-Bus system bus connection, redialing it
+// if a previous scrape found it closed out from under us. c.connMu is held
+// for the remainder of the scrape by the caller, so the returned conn can't
+// be closed by a concurrent scrape while still in use.
+func (c *Collector) connection() (*dbus.Conn, error) {
+	if c.conn != nil && !c.conn.Connected() {
+		c.logger.Warn("D-Bus connection is no longer connected, reconnecting")
+		c.conn.Close()
+		c.conn = nil
+	}
+
+	if c.conn == nil {
+		conn, err := dbus.ConnectSystemBus()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get DBus connection")
+		}
+		c.conn = conn
+	}
+
+	return c.conn, nil
+}
+
+// invalidateConnection drops the cached connection when a call on it fails
+// with a closed-connection error, so the next scrape redials instead of
+// reusing a dead socket. Caller holds c.connMu.
+func (c *Collector) invalidateConnection(err error) {
+	if !isConnClosedErr(err) {
+		return
+	}
+
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+	}
+}
+
+// isConnClosedErr reports whether err looks like it came from a closed
+// D-Bus connection rather than an ordinary D-Bus method error.
+func isConnClosedErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	if err == io.EOF {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "use of closed network connection") || strings.Contains(msg, "closed connection")
+}
+
+// closeOnContextDone waits for c.ctx to be canceled and closes the cached
+// D-Bus connection, so the exporter's shutdown path just needs to cancel
+// the context it passed to NewCollector.
+func (c *Collector) closeOnContextDone() {
+	<-c.ctx.Done()
+	if err := c.Close(); err != nil {
+		c.logger.Warn("error closing D-Bus connection", "err", err)
+	}
+}
+
+// Close releases the cached D-Bus connection. Safe to call directly during
+// exporter shutdown in addition to, or instead of, canceling c.ctx.
+func (c *Collector) Close() error {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
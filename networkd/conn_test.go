@@ -0,0 +1,47 @@
+// Copyright 2023 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkd
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestCloseNilConn(t *testing.T) {
+	c := &Collector{logger: slog.Default()}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() on a Collector with no cached conn = %v, want nil", err)
+	}
+}
+
+func TestCloseOnContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Collector{ctx: ctx, logger: slog.Default()}
+
+	done := make(chan struct{})
+	go func() {
+		c.closeOnContextDone()
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("closeOnContextDone did not return after context cancellation")
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkd
+
+import (
+	"encoding/hex"
+	"net"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// dhcpLease is one decoded entry of the DHCPServer.Leases D-Bus property,
+// whose value is an array of (family, mac, addr, hostname, client_id,
+// expiration) tuples.
+type dhcpLease struct {
+	family     int32
+	mac        net.HardwareAddr
+	addr       net.IP
+	hostname   string
+	clientID   string
+	expiration uint64 // usec since the Unix epoch
+}
+
+// decodeDHCPLease converts one untyped D-Bus struct, as returned by
+// dbus.Store into []interface{}, into a dhcpLease.
+func decodeDHCPLease(v []interface{}) dhcpLease {
+	return dhcpLease{
+		family:     v[0].(int32),
+		mac:        net.HardwareAddr(v[1].([]byte)),
+		addr:       net.IP(v[2].([]byte)),
+		hostname:   v[3].(string),
+		clientID:   hex.EncodeToString(v[4].([]byte)),
+		expiration: v[5].(uint64),
+	}
+}
+
+// collectDHCPLeases emits lease_info and lease_expiration_timestamp_seconds
+// for each decoded entry of the DHCPServer.Leases property.
+func (c *Collector) collectDHCPLeases(ch chan<- prometheus.Metric, iface string, leases [][]interface{}) {
+	for _, raw := range leases {
+		lease := decodeDHCPLease(raw)
+
+		ch <- prometheus.MustNewConstMetric(c.lease_info, prometheus.GaugeValue, 1,
+			iface, lease.addr.String(), lease.mac.String(), lease.hostname, lease.clientID)
+		ch <- prometheus.MustNewConstMetric(c.lease_expiration, prometheus.GaugeValue,
+			float64(lease.expiration)/1e6, iface, lease.mac.String())
+	}
+}
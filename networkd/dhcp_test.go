@@ -0,0 +1,52 @@
+// Copyright 2023 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkd
+
+import (
+	"net"
+	"testing"
+)
+
+func TestDecodeDHCPLease(t *testing.T) {
+	v := []interface{}{
+		int32(2),
+		[]byte{0xde, 0xad, 0xbe, 0xef, 0x00, 0x01},
+		[]byte{192, 168, 1, 42},
+		"myhost",
+		[]byte{0x01, 0x02, 0x03},
+		uint64(1_700_000_000_000_000),
+	}
+
+	lease := decodeDHCPLease(v)
+
+	if got, want := lease.family, int32(2); got != want {
+		t.Errorf("family = %d, want %d", got, want)
+	}
+	wantMAC := net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0x00, 0x01}.String()
+	if got, want := lease.mac.String(), wantMAC; got != want {
+		t.Errorf("mac = %q, want %q", got, want)
+	}
+	if got, want := lease.addr.String(), "192.168.1.42"; got != want {
+		t.Errorf("addr = %q, want %q", got, want)
+	}
+	if got, want := lease.hostname, "myhost"; got != want {
+		t.Errorf("hostname = %q, want %q", got, want)
+	}
+	if got, want := lease.clientID, "010203"; got != want {
+		t.Errorf("clientID = %q, want %q", got, want)
+	}
+	if got, want := lease.expiration, uint64(1_700_000_000_000_000); got != want {
+		t.Errorf("expiration = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkd
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/alecthomas/kingpin/v2"
+)
+
+var (
+	deviceInclude = kingpin.Flag(
+		"collector.networkd.device-include",
+		"Regexp of networkd devices to include (mutually exclusive with device-exclude).",
+	).String()
+	deviceExclude = kingpin.Flag(
+		"collector.networkd.device-exclude",
+		"Regexp of networkd devices to exclude (mutually exclusive with device-include).",
+	).String()
+)
+
+// deviceFilter skips links by name before any per-link D-Bus or rtnetlink
+// calls are made, the same include/exclude pattern node_exporter's
+// diskstats and netdev collectors use.
+type deviceFilter struct {
+	ignoredPattern  *regexp.Regexp
+	acceptedPattern *regexp.Regexp
+}
+
+// newDeviceFilter compiles the include/exclude flags into a deviceFilter.
+// The two flags are mutually exclusive.
+func newDeviceFilter(include, exclude string) (*deviceFilter, error) {
+	if include != "" && exclude != "" {
+		return nil, fmt.Errorf("device-include and device-exclude flags are mutually exclusive")
+	}
+
+	f := &deviceFilter{}
+	if include != "" {
+		pattern, err := regexp.Compile(include)
+		if err != nil {
+			return nil, fmt.Errorf("invalid device-include regexp: %w", err)
+		}
+		f.acceptedPattern = pattern
+	}
+	if exclude != "" {
+		pattern, err := regexp.Compile(exclude)
+		if err != nil {
+			return nil, fmt.Errorf("invalid device-exclude regexp: %w", err)
+		}
+		f.ignoredPattern = pattern
+	}
+	return f, nil
+}
+
+// ignored reports whether a link name should be skipped.
+func (f *deviceFilter) ignored(name string) bool {
+	if f == nil {
+		return false
+	}
+	if f.ignoredPattern != nil && f.ignoredPattern.MatchString(name) {
+		return true
+	}
+	if f.acceptedPattern != nil && !f.acceptedPattern.MatchString(name) {
+		return true
+	}
+	return false
+}
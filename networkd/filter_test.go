@@ -0,0 +1,66 @@
+// Copyright 2023 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkd
+
+import "testing"
+
+func TestNewDeviceFilterMutuallyExclusive(t *testing.T) {
+	if _, err := newDeviceFilter("eth.*", "veth.*"); err == nil {
+		t.Fatal("expected an error when both include and exclude are set")
+	}
+}
+
+func TestNewDeviceFilterInvalidRegexp(t *testing.T) {
+	if _, err := newDeviceFilter("(", ""); err == nil {
+		t.Fatal("expected an error for an invalid include regexp")
+	}
+	if _, err := newDeviceFilter("", "("); err == nil {
+		t.Fatal("expected an error for an invalid exclude regexp")
+	}
+}
+
+func TestDeviceFilterIgnored(t *testing.T) {
+	tests := []struct {
+		name    string
+		include string
+		exclude string
+		device  string
+		want    bool
+	}{
+		{"no filter", "", "", "eth0", false},
+		{"matches include", "^eth", "", "eth0", false},
+		{"does not match include", "^eth", "", "veth0", true},
+		{"matches exclude", "", "^veth", "veth0", true},
+		{"does not match exclude", "", "^veth", "eth0", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := newDeviceFilter(tc.include, tc.exclude)
+			if err != nil {
+				t.Fatalf("newDeviceFilter: %v", err)
+			}
+			if got := f.ignored(tc.device); got != tc.want {
+				t.Errorf("ignored(%q) = %v, want %v", tc.device, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNilDeviceFilterNeverIgnores(t *testing.T) {
+	var f *deviceFilter
+	if f.ignored("eth0") {
+		t.Fatal("nil deviceFilter should never ignore a device")
+	}
+}
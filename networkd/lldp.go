@@ -0,0 +1,97 @@
+// Copyright 2023 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkd
+
+import (
+	"encoding/binary"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// LLDP TLV types, as defined by IEEE 802.1AB. networkd.Link.LLDPNeighbors
+// exposes each neighbor as the raw TLV stream received off the wire, so we
+// have to walk it ourselves rather than rely on pre-parsed D-Bus fields.
+const (
+	lldpTLVEnd                = 0
+	lldpTLVChassisID          = 1
+	lldpTLVPortID             = 2
+	lldpTLVTTL                = 3
+	lldpTLVPortDescription    = 4
+	lldpTLVSystemName         = 5
+	lldpTLVSystemCapabilities = 7
+)
+
+// lldpNeighbor holds the subset of a decoded LLDP neighbor advertisement
+// this collector exposes as metrics.
+type lldpNeighbor struct {
+	chassisID       string
+	portID          string
+	systemName      string
+	portDescription string
+	capabilities    string
+}
+
+// decodeLLDPNeighbor walks the raw TLV stream of a single LLDP advertisement
+// and extracts the chassis ID, port ID, system name, port description, and
+// capabilities bitmap TLVs.
+func decodeLLDPNeighbor(raw []byte) lldpNeighbor {
+	var n lldpNeighbor
+
+	for len(raw) >= 2 {
+		header := binary.BigEndian.Uint16(raw[0:2])
+		tlvType := header >> 9
+		tlvLen := int(header & 0x1ff)
+		raw = raw[2:]
+		if tlvType == lldpTLVEnd || len(raw) < tlvLen {
+			break
+		}
+		value := raw[:tlvLen]
+		raw = raw[tlvLen:]
+
+		switch tlvType {
+		case lldpTLVChassisID:
+			if len(value) > 1 {
+				n.chassisID = string(value[1:])
+			}
+		case lldpTLVPortID:
+			if len(value) > 1 {
+				n.portID = string(value[1:])
+			}
+		case lldpTLVSystemName:
+			n.systemName = string(value)
+		case lldpTLVPortDescription:
+			n.portDescription = string(value)
+		case lldpTLVSystemCapabilities:
+			if len(value) >= 2 {
+				n.capabilities = strconv.FormatUint(uint64(binary.BigEndian.Uint16(value[0:2])), 2)
+			}
+		}
+	}
+
+	return n
+}
+
+// collectLLDPNeighbors decodes each raw LLDP advertisement already fetched
+// from the Link.LLDPNeighbors property and emits lldp_neighbor_info and
+// lldp_neighbors_total.
+func (c *Collector) collectLLDPNeighbors(ch chan<- prometheus.Metric, iface string, neighbors [][]byte) {
+	for _, raw := range neighbors {
+		n := decodeLLDPNeighbor(raw)
+		ch <- prometheus.MustNewConstMetric(c.lldp_neighbor_info, prometheus.GaugeValue, 1,
+			iface, n.chassisID, n.portID, n.systemName, n.portDescription, n.capabilities)
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.lldp_neighbors_total, prometheus.GaugeValue, float64(len(neighbors)), iface)
+}
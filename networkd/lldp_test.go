@@ -0,0 +1,76 @@
+// Copyright 2023 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkd
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// lldpTLV builds one raw TLV: a 2-byte (7-bit type, 9-bit length) header
+// followed by value.
+func lldpTLV(tlvType uint16, value []byte) []byte {
+	header := make([]byte, 2)
+	binary.BigEndian.PutUint16(header, tlvType<<9|uint16(len(value)))
+	return append(header, value...)
+}
+
+func TestDecodeLLDPNeighbor(t *testing.T) {
+	var raw []byte
+	raw = append(raw, lldpTLV(lldpTLVChassisID, append([]byte{4}, []byte("chassis1")...))...)
+	raw = append(raw, lldpTLV(lldpTLVPortID, append([]byte{2}, []byte("eth0")...))...)
+	raw = append(raw, lldpTLV(lldpTLVTTL, []byte{0x00, 0x78})...)
+	raw = append(raw, lldpTLV(lldpTLVPortDescription, []byte("uplink port"))...)
+	raw = append(raw, lldpTLV(lldpTLVSystemName, []byte("switch1"))...)
+	raw = append(raw, lldpTLV(lldpTLVSystemCapabilities, []byte{0x00, 0x04})...)
+	raw = append(raw, lldpTLV(lldpTLVEnd, nil)...)
+
+	n := decodeLLDPNeighbor(raw)
+
+	if got, want := n.chassisID, "chassis1"; got != want {
+		t.Errorf("chassisID = %q, want %q", got, want)
+	}
+	if got, want := n.portID, "eth0"; got != want {
+		t.Errorf("portID = %q, want %q", got, want)
+	}
+	if got, want := n.systemName, "switch1"; got != want {
+		t.Errorf("systemName = %q, want %q", got, want)
+	}
+	if got, want := n.portDescription, "uplink port"; got != want {
+		t.Errorf("portDescription = %q, want %q", got, want)
+	}
+	if got, want := n.capabilities, "100"; got != want {
+		t.Errorf("capabilities = %q, want %q", got, want)
+	}
+}
+
+func TestDecodeLLDPNeighborTruncated(t *testing.T) {
+	// A TLV header that claims more bytes than are actually present must
+	// not panic; decoding should just stop.
+	raw := lldpTLV(lldpTLVSystemName, []byte("a"))
+	raw[1] = 0xff // inflate the declared length past the actual payload
+
+	n := decodeLLDPNeighbor(raw)
+
+	if n.systemName != "" {
+		t.Errorf("systemName = %q, want empty for a truncated TLV", n.systemName)
+	}
+}
+
+func TestDecodeLLDPNeighborEmpty(t *testing.T) {
+	n := decodeLLDPNeighbor(nil)
+	if n != (lldpNeighbor{}) {
+		t.Errorf("decodeLLDPNeighbor(nil) = %+v, want zero value", n)
+	}
+}
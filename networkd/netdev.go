@@ -0,0 +1,104 @@
+// Copyright 2023 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkd
+
+import (
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/jsimonetti/rtnetlink"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var enableDetailedNetdevMetrics = kingpin.Flag(
+	"collector.networkd.enable-detailed-netdev-metrics",
+	"Collect detailed per-interface netdev statistics via rtnetlink (IFLA_STATS64).",
+).Default("false").Bool()
+
+// netdevStatSpec describes a single IFLA_STATS64 counter: its metric name
+// suffix, help text, and how to pull the value out of a LinkStats64.
+type netdevStatSpec struct {
+	name  string
+	help  string
+	value func(*rtnetlink.LinkStats64) uint64
+}
+
+var netdevStatSpecs = []netdevStatSpec{
+	{"receive_bytes_total", "Total number of bytes received", func(s *rtnetlink.LinkStats64) uint64 { return s.RXBytes }},
+	{"receive_packets_total", "Total number of packets received", func(s *rtnetlink.LinkStats64) uint64 { return s.RXPackets }},
+	{"receive_errors_total", "Total number of receive errors", func(s *rtnetlink.LinkStats64) uint64 { return s.RXErrors }},
+	{"receive_dropped_total", "Total number of received packets dropped", func(s *rtnetlink.LinkStats64) uint64 { return s.RXDropped }},
+	{"receive_missed_errors_total", "Total number of missed receive packets due to buffer overflow", func(s *rtnetlink.LinkStats64) uint64 { return s.RXMissedErrors }},
+	{"receive_fifo_errors_total", "Total number of receive FIFO errors", func(s *rtnetlink.LinkStats64) uint64 { return s.RXFIFOErrors }},
+	{"receive_length_errors", "Total number of receive length errors", func(s *rtnetlink.LinkStats64) uint64 { return s.RXLengthErrors }},
+	{"receive_over_errors", "Total number of receive over errors", func(s *rtnetlink.LinkStats64) uint64 { return s.RXOverErrors }},
+	{"receive_crc_errors", "Total number of receive CRC errors", func(s *rtnetlink.LinkStats64) uint64 { return s.RXCRCErrors }},
+	{"receive_frame_errors", "Total number of receive frame alignment errors", func(s *rtnetlink.LinkStats64) uint64 { return s.RXFrameErrors }},
+	{"multicast_total", "Total number of multicast packets received", func(s *rtnetlink.LinkStats64) uint64 { return s.Multicast }},
+	{"transmit_bytes_total", "Total number of bytes transmitted", func(s *rtnetlink.LinkStats64) uint64 { return s.TXBytes }},
+	{"transmit_packets_total", "Total number of packets transmitted", func(s *rtnetlink.LinkStats64) uint64 { return s.TXPackets }},
+	{"transmit_errors_total", "Total number of transmit errors", func(s *rtnetlink.LinkStats64) uint64 { return s.TXErrors }},
+	{"transmit_dropped_total", "Total number of transmitted packets dropped", func(s *rtnetlink.LinkStats64) uint64 { return s.TXDropped }},
+	{"transmit_fifo_errors_total", "Total number of transmit FIFO errors", func(s *rtnetlink.LinkStats64) uint64 { return s.TXFIFOErrors }},
+	{"transmit_aborted_errors", "Total number of transmit aborted errors", func(s *rtnetlink.LinkStats64) uint64 { return s.TXAbortedErrors }},
+	{"transmit_carrier_errors", "Total number of transmit carrier errors", func(s *rtnetlink.LinkStats64) uint64 { return s.TXCarrierErrors }},
+	{"transmit_heartbeat_errors", "Total number of transmit heartbeat errors", func(s *rtnetlink.LinkStats64) uint64 { return s.TXHeartbeatErrors }},
+	{"transmit_window_errors", "Total number of transmit window errors", func(s *rtnetlink.LinkStats64) uint64 { return s.TXWindowErrors }},
+	{"collisions_total", "Total number of collisions detected on the interface", func(s *rtnetlink.LinkStats64) uint64 { return s.Collisions }},
+}
+
+// newNetdevDescs builds the prometheus.Desc for every netdevStatSpec.
+func newNetdevDescs() map[string]*prometheus.Desc {
+	descs := make(map[string]*prometheus.Desc, len(netdevStatSpecs))
+	for _, spec := range netdevStatSpecs {
+		descs[spec.name] = prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "netdev", spec.name),
+			spec.help,
+			[]string{"iface"}, nil,
+		)
+	}
+	return descs
+}
+
+// collectNetdev reads IFLA_STATS64 counters for every interface via
+// rtnetlink and emits them as the networkd_netdev_* metrics.
+func (c *Collector) collectNetdev(ch chan<- prometheus.Metric) error {
+	conn, err := rtnetlink.Dial(nil)
+	if err != nil {
+		return errors.Wrapf(err, "could not dial rtnetlink")
+	}
+	defer conn.Close()
+
+	links, err := conn.Link.List()
+	if err != nil {
+		return errors.Wrapf(err, "could not list rtnetlink links")
+	}
+
+	for _, link := range links {
+		if link.Attributes == nil || link.Attributes.Stats64 == nil {
+			continue
+		}
+		stats := link.Attributes.Stats64
+		iface := link.Attributes.Name
+
+		if c.deviceFilter.ignored(iface) {
+			continue
+		}
+
+		for _, spec := range netdevStatSpecs {
+			ch <- prometheus.MustNewConstMetric(c.netdevDescs[spec.name], prometheus.CounterValue, float64(spec.value(stats)), iface)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkd
+
+import (
+	"testing"
+
+	"github.com/jsimonetti/rtnetlink"
+)
+
+func TestNetdevStatSpecsValue(t *testing.T) {
+	stats := &rtnetlink.LinkStats64{
+		RXBytes:           1,
+		RXPackets:         2,
+		RXErrors:          3,
+		RXDropped:         4,
+		RXMissedErrors:    5,
+		RXFIFOErrors:      6,
+		RXLengthErrors:    7,
+		RXOverErrors:      8,
+		RXCRCErrors:       9,
+		RXFrameErrors:     10,
+		Multicast:         11,
+		TXBytes:           12,
+		TXPackets:         13,
+		TXErrors:          14,
+		TXDropped:         15,
+		TXFIFOErrors:      16,
+		TXAbortedErrors:   17,
+		TXCarrierErrors:   18,
+		TXHeartbeatErrors: 19,
+		TXWindowErrors:    20,
+		Collisions:        21,
+	}
+
+	want := map[string]uint64{
+		"receive_bytes_total":         1,
+		"receive_packets_total":       2,
+		"receive_errors_total":        3,
+		"receive_dropped_total":       4,
+		"receive_missed_errors_total": 5,
+		"receive_fifo_errors_total":   6,
+		"receive_length_errors":       7,
+		"receive_over_errors":         8,
+		"receive_crc_errors":          9,
+		"receive_frame_errors":        10,
+		"multicast_total":             11,
+		"transmit_bytes_total":        12,
+		"transmit_packets_total":      13,
+		"transmit_errors_total":       14,
+		"transmit_dropped_total":      15,
+		"transmit_fifo_errors_total":  16,
+		"transmit_aborted_errors":     17,
+		"transmit_carrier_errors":     18,
+		"transmit_heartbeat_errors":   19,
+		"transmit_window_errors":      20,
+		"collisions_total":            21,
+	}
+
+	if len(netdevStatSpecs) != len(want) {
+		t.Fatalf("got %d netdevStatSpecs, want %d", len(netdevStatSpecs), len(want))
+	}
+
+	for _, spec := range netdevStatSpecs {
+		wantValue, ok := want[spec.name]
+		if !ok {
+			t.Errorf("unexpected netdevStatSpec %q", spec.name)
+			continue
+		}
+		if got := spec.value(stats); got != wantValue {
+			t.Errorf("netdevStatSpecs[%q].value() = %d, want %d", spec.name, got, wantValue)
+		}
+	}
+}
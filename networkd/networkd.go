@@ -15,9 +15,9 @@ package networkd
 
 import (
 	"context"
+	"log/slog"
+	"sync"
 
-	"github.com/go-kit/log"
-	"github.com/go-kit/log/level"
 	"github.com/godbus/dbus/v5"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -25,18 +25,38 @@ import (
 
 const namespace = "networkd"
 
+// Possible values of the networkd Link CarrierState, OnlineState, and
+// OperationalState D-Bus properties, per systemd-networkd(8). Enumerated
+// here so every known state gets its own time series, node_exporter-style.
+var (
+	linkCarrierStates     = []string{"off", "no-carrier", "carrier", "degraded-carrier"}
+	linkOnlineStates      = []string{"offline", "partial", "online"}
+	linkOperationalStates = []string{"off", "no-carrier", "dormant", "degraded-carrier", "carrier", "degraded", "enslaved", "routable"}
+)
+
 type Collector struct {
 	ctx                    context.Context
-	logger                 log.Logger
+	logger                 *slog.Logger
 	leases                 *prometheus.Desc
 	links                  *prometheus.Desc
 	link_carrier_state     *prometheus.Desc
 	link_online_state      *prometheus.Desc
 	link_operational_state *prometheus.Desc
+	lease_info             *prometheus.Desc
+	lease_expiration       *prometheus.Desc
+	lldp_neighbor_info     *prometheus.Desc
+	lldp_neighbors_total   *prometheus.Desc
+	netdevDescs            map[string]*prometheus.Desc
+	deviceFilter           *deviceFilter
+	connMu                 sync.Mutex
+	conn                   *dbus.Conn
 }
 
-// NewCollector returns a new Collector exporing networkd statistics
-func NewCollector(logger log.Logger) (*Collector, error) {
+// NewCollector returns a new Collector exporing networkd statistics. The
+// cached D-Bus connection is closed automatically once ctx is canceled,
+// which is how the exporter should drive cleanup on shutdown; it can also
+// be closed directly via (*Collector).Close.
+func NewCollector(ctx context.Context, logger *slog.Logger) (*Collector, error) {
 	leases := prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "dhcpserver_leases_total"),
 		"networkd DHCP server leases",
@@ -47,22 +67,84 @@ func NewCollector(logger log.Logger) (*Collector, error) {
 		"networkd links",
 		nil, nil,
 	)
+	link_carrier_state := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "link_carrier_state"),
+		"networkd link carrier state, value 1 for the link's current state",
+		[]string{"iface", "state"}, nil,
+	)
+	link_online_state := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "link_online_state"),
+		"networkd link online state, value 1 for the link's current state",
+		[]string{"iface", "state"}, nil,
+	)
+	link_operational_state := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "link_operational_state"),
+		"networkd link operational state, value 1 for the link's current state",
+		[]string{"iface", "state"}, nil,
+	)
+
+	lease_info := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dhcpserver_lease_info"),
+		"networkd DHCP server lease, value 1 per active lease",
+		[]string{"iface", "client_ip", "client_mac", "hostname", "client_id"}, nil,
+	)
+	lease_expiration := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "dhcpserver_lease_expiration_timestamp_seconds"),
+		"networkd DHCP server lease expiration time, in unixtime",
+		[]string{"iface", "client_mac"}, nil,
+	)
+
+	lldp_neighbor_info := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "lldp_neighbor_info"),
+		"networkd LLDP neighbor, value 1 per received advertisement",
+		[]string{"iface", "chassis_id", "port_id", "system_name", "port_description", "capabilities"}, nil,
+	)
+	lldp_neighbors_total := prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "lldp_neighbors_total"),
+		"networkd LLDP neighbors",
+		[]string{"iface"}, nil,
+	)
 
-	ctx := context.TODO()
-	return &Collector{
+	var netdevDescs map[string]*prometheus.Desc
+	if *enableDetailedNetdevMetrics {
+		netdevDescs = newNetdevDescs()
+	}
+
+	filter, err := newDeviceFilter(*deviceInclude, *deviceExclude)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := dbus.ConnectSystemBus()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get DBus connection")
+	}
+
+	c := &Collector{
 		ctx:                    ctx,
 		logger:                 logger,
 		leases:                 leases,
 		links:                  links,
-	}, nil
+		link_carrier_state:     link_carrier_state,
+		link_online_state:      link_online_state,
+		link_operational_state: link_operational_state,
+		lease_info:             lease_info,
+		lease_expiration:       lease_expiration,
+		lldp_neighbor_info:     lldp_neighbor_info,
+		lldp_neighbors_total:   lldp_neighbors_total,
+		netdevDescs:            netdevDescs,
+		deviceFilter:           filter,
+		conn:                   conn,
+	}
+	go c.closeOnContextDone()
+	return c, nil
 }
 
 // Collect gathers metrics from networkd
 func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	err := c.collect(ch)
 	if err != nil {
-		level.Error(c.logger).Log("msg", "error collecting metrics",
-			"err", err)
+		c.logger.Error("error collecting metrics", "err", err)
 	}
 }
 
@@ -70,14 +152,44 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 func (c *Collector) Describe(desc chan<- *prometheus.Desc) {
 	desc <- c.leases
 	desc <- c.links
+	desc <- c.link_carrier_state
+	desc <- c.link_online_state
+	desc <- c.link_operational_state
+	desc <- c.lease_info
+	desc <- c.lease_expiration
+	desc <- c.lldp_neighbor_info
+	desc <- c.lldp_neighbors_total
+	for _, d := range c.netdevDescs {
+		desc <- d
+	}
+}
+
+// emitEnum emits one metric per possible value of an enum-valued property,
+// with value 1 for the link's current state and 0 for every other state.
+func emitEnum(ch chan<- prometheus.Metric, desc *prometheus.Desc, states []string, current, iface string) {
+	for _, state := range states {
+		value := 0.0
+		if state == current {
+			value = 1.0
+		}
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, iface, state)
+	}
 }
 
 func (c *Collector) collect(ch chan<- prometheus.Metric) error {
-	conn, err := dbus.ConnectSystemBus()
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	conn, err := c.connection()
 	if err != nil {
-		return errors.Wrapf(err, "could not get DBus connection")
+		return err
+	}
+
+	if *enableDetailedNetdevMetrics {
+		if err := c.collectNetdev(ch); err != nil {
+			c.logger.Warn("Unable to collect detailed netdev metrics", "err", err)
+		}
 	}
-	defer conn.Close()
 
 	obj := conn.Object("org.freedesktop.network1", "/org/freedesktop/network1")
 
@@ -85,7 +197,9 @@ func (c *Collector) collect(ch chan<- prometheus.Metric) error {
 
 	err = obj.Call("org.freedesktop.network1.Manager.ListLinks", 0).Store(&links)
 	if err != nil {
-		return level.Warn(c.logger).Log("msg", "Unable to list networkd links", "err", err)
+		c.invalidateConnection(err)
+		c.logger.Warn("Unable to list networkd links", "err", err)
+		return nil
 	}
 
 	// Record number of links
@@ -96,17 +210,50 @@ func (c *Collector) collect(ch chan<- prometheus.Metric) error {
 		link_obj_iface := v[1].(string)
 		link_obj_path := v[2].(dbus.ObjectPath)
 
+		if c.deviceFilter.ignored(link_obj_iface) {
+			continue
+		}
+
 		link_obj := conn.Object("org.freedesktop.network1", link_obj_path)
 
+		carrier_state_property, err := link_obj.GetProperty("org.freedesktop.network1.Link.CarrierState")
+		if err != nil {
+			c.logger.Debug("Unable to get CarrierState for link", "iface", link_obj_iface, "err", err)
+		} else {
+			emitEnum(ch, c.link_carrier_state, linkCarrierStates, carrier_state_property.Value().(string), link_obj_iface)
+		}
+
+		online_state_property, err := link_obj.GetProperty("org.freedesktop.network1.Link.OnlineState")
+		if err != nil {
+			c.logger.Debug("Unable to get OnlineState for link", "iface", link_obj_iface, "err", err)
+		} else {
+			emitEnum(ch, c.link_online_state, linkOnlineStates, online_state_property.Value().(string), link_obj_iface)
+		}
+
+		operational_state_property, err := link_obj.GetProperty("org.freedesktop.network1.Link.OperationalState")
+		if err != nil {
+			c.logger.Debug("Unable to get OperationalState for link", "iface", link_obj_iface, "err", err)
+		} else {
+			emitEnum(ch, c.link_operational_state, linkOperationalStates, operational_state_property.Value().(string), link_obj_iface)
+		}
+
+		lldp_neighbors_property, err := link_obj.GetProperty("org.freedesktop.network1.Link.LLDPNeighbors")
+		if err != nil {
+			c.logger.Debug("Unable to get LLDPNeighbors for link", "iface", link_obj_iface, "err", err)
+		} else {
+			c.collectLLDPNeighbors(ch, link_obj_iface, lldp_neighbors_property.Value().([][]byte))
+		}
+
 		leases_property, err := link_obj.GetProperty("org.freedesktop.network1.DHCPServer.Leases")
 		if err != nil {
 			// No leases found
-			level.Debug(c.logger).Log("msg", "No leases found for interface", "err", err)
+			c.logger.Debug("No leases found for interface", "iface", link_obj_iface, "err", err)
 			continue
 		}
 
-		leases_count := len(leases_property.Value().([][]interface{}))
-		ch <- prometheus.MustNewConstMetric(c.leases, prometheus.GaugeValue, float64(leases_count), link_obj_iface)
+		leases := leases_property.Value().([][]interface{})
+		ch <- prometheus.MustNewConstMetric(c.leases, prometheus.GaugeValue, float64(len(leases)), link_obj_iface)
+		c.collectDHCPLeases(ch, link_obj_iface, leases)
 	}
 
 	return nil
@@ -0,0 +1,52 @@
+// Copyright 2023 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkd
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestEmitEnum(t *testing.T) {
+	desc := prometheus.NewDesc("test_state", "test state", []string{"iface", "state"}, nil)
+	states := []string{"off", "carrier", "no-carrier"}
+
+	ch := make(chan prometheus.Metric, len(states))
+	emitEnum(ch, desc, states, "carrier", "eth0")
+	close(ch)
+
+	got := map[string]float64{}
+	for m := range ch {
+		var pb dto.Metric
+		if err := m.Write(&pb); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		var state string
+		for _, l := range pb.GetLabel() {
+			if l.GetName() == "state" {
+				state = l.GetValue()
+			}
+		}
+		got[state] = pb.GetGauge().GetValue()
+	}
+
+	want := map[string]float64{"off": 0, "carrier": 1, "no-carrier": 0}
+	for state, wantValue := range want {
+		if got[state] != wantValue {
+			t.Errorf("state %q = %v, want %v", state, got[state], wantValue)
+		}
+	}
+}